@@ -0,0 +1,129 @@
+package goa
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// contextKey is the type used to store values in the request context, it is
+// unexported to prevent collisions with keys from other packages.
+type contextKey int
+
+const (
+	reqKey contextKey = iota + 1
+	respKey
+	serviceKey
+)
+
+type (
+	// RequestData provides access to the underlying HTTP request along with
+	// the path and query string parameters extracted and validated by the
+	// generated code. Use ContextRequest to retrieve it from a handler
+	// context.
+	RequestData struct {
+		*http.Request
+		// Params contains the raw path and query string parameter values.
+		Params url.Values
+	}
+
+	// ResponseData wraps the HTTP response writer given to a handler so the
+	// framework can keep track of whether a response was written and its
+	// status code and length. Use ContextResponse to retrieve it from a
+	// handler context.
+	ResponseData struct {
+		http.ResponseWriter
+		// Status is the response status code, 0 until WriteHeader or Write is
+		// called.
+		Status int
+		// Length is the number of bytes written to the response body so far.
+		Length int
+	}
+)
+
+// NewContext builds the context given to action handlers. It is exposed for
+// the benefit of the generated code; user code should not need to call it
+// directly.
+func NewContext(ctx context.Context, rw http.ResponseWriter, req *http.Request, params url.Values) context.Context {
+	var reqData *RequestData
+	var respData *ResponseData
+	if svc := ContextService(ctx); svc != nil {
+		reqData, respData = svc.acquireRequestState()
+	}
+	if reqData == nil {
+		reqData = &RequestData{}
+	}
+	if respData == nil {
+		respData = &ResponseData{}
+	}
+	reqData.Request = req
+	reqData.Params = params
+	respData.ResponseWriter = rw
+	respData.Status = 0
+	respData.Length = 0
+	ctx = context.WithValue(ctx, reqKey, reqData)
+	ctx = context.WithValue(ctx, respKey, respData)
+	return ctx
+}
+
+// ContextRequest extracts the RequestData stored in ctx by NewContext.
+func ContextRequest(ctx context.Context) *RequestData {
+	r, _ := ctx.Value(reqKey).(*RequestData)
+	return r
+}
+
+// ContextResponse extracts the ResponseData stored in ctx by NewContext.
+func ContextResponse(ctx context.Context) *ResponseData {
+	r, _ := ctx.Value(respKey).(*ResponseData)
+	return r
+}
+
+// ContextService extracts the Service associated with ctx, set by
+// Service.NewController.
+func ContextService(ctx context.Context) *Service {
+	s, _ := ctx.Value(serviceKey).(*Service)
+	return s
+}
+
+// Written returns true if the response was already written to.
+func (r *ResponseData) Written() bool {
+	return r.Status != 0
+}
+
+// WriteHeader records the response status then writes it to the underlying
+// response writer.
+func (r *ResponseData) WriteHeader(code int) {
+	r.Status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the number of bytes written then writes them to the
+// underlying response writer, defaulting the status to 200 if not already
+// set - matching the http.ResponseWriter contract.
+func (r *ResponseData) Write(b []byte) (int, error) {
+	if r.Status == 0 {
+		r.Status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.Length += n
+	return n, err
+}
+
+// Send writes resp as the response body with the given status code, encoding
+// it using the content type negotiated by the service associated with ctx.
+// The content type is negotiated - which sets the "Content-Type" header -
+// before the status is written, since headers set after WriteHeader has no
+// effect on the response. When ctx carries no service, Send writes only the
+// status and resp is not written.
+func (r *ResponseData) Send(ctx context.Context, status int, resp interface{}) error {
+	svc := ContextService(ctx)
+	if svc == nil {
+		r.WriteHeader(status)
+		return nil
+	}
+	enc := svc.Encoder(ctx, r)
+	defer svc.ReleaseEncoder(r, enc)
+	r.WriteHeader(status)
+	return enc.Encode(resp)
+}
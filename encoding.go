@@ -0,0 +1,259 @@
+package goa
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	goahttp "goa.design/goa/http"
+)
+
+type (
+	// decoderPool caches goahttp.Decoder values built by factory for a single
+	// media type so that handling a request does not need to allocate a new
+	// decoder. Pooled decoders are recycled via Reset when they implement
+	// resettableDecoder; others are simply discarded after use.
+	decoderPool struct {
+		factory func(io.Reader) goahttp.Decoder
+		pool    sync.Pool
+	}
+
+	// encoderPool is the encoder counterpart of decoderPool.
+	encoderPool struct {
+		factory func(io.Writer) goahttp.Encoder
+		pool    sync.Pool
+	}
+
+	// resettableDecoder is implemented by decoders that can be rebound to a
+	// new reader instead of being recreated, making them poolable.
+	resettableDecoder interface {
+		goahttp.Decoder
+		Reset(io.Reader)
+	}
+
+	// resettableEncoder is the encoder counterpart of resettableDecoder.
+	resettableEncoder interface {
+		goahttp.Encoder
+		Reset(io.Writer)
+	}
+
+	// acceptEntry is one comma-separated element of an Accept header together
+	// with its "q" weight.
+	acceptEntry struct {
+		mediaType string
+		q         float64
+	}
+)
+
+func newDecoderPool(factory func(io.Reader) goahttp.Decoder) *decoderPool {
+	return &decoderPool{factory: factory}
+}
+
+func (p *decoderPool) Get(r io.Reader) goahttp.Decoder {
+	if v := p.pool.Get(); v != nil {
+		d := v.(resettableDecoder)
+		d.Reset(r)
+		return d
+	}
+	return p.factory(r)
+}
+
+func (p *decoderPool) Put(d goahttp.Decoder) {
+	if _, ok := d.(resettableDecoder); ok {
+		p.pool.Put(d)
+	}
+}
+
+func newEncoderPool(factory func(io.Writer) goahttp.Encoder) *encoderPool {
+	return &encoderPool{factory: factory}
+}
+
+func (p *encoderPool) Get(w io.Writer) goahttp.Encoder {
+	if v := p.pool.Get(); v != nil {
+		e := v.(resettableEncoder)
+		e.Reset(w)
+		return e
+	}
+	return p.factory(w)
+}
+
+func (p *encoderPool) Put(e goahttp.Encoder) {
+	if _, ok := e.(resettableEncoder); ok {
+		p.pool.Put(e)
+	}
+}
+
+// RegisterDecoder registers factory as the source of goahttp.Decoder values
+// for requests whose (possibly negotiated) Content-Type matches one of
+// mediaTypes. Decoders built by factory are pooled automatically when they
+// implement Reset(io.Reader).
+func (service *Service) RegisterDecoder(factory func(io.Reader) goahttp.Decoder, mediaTypes ...string) {
+	p := newDecoderPool(factory)
+	for _, mt := range mediaTypes {
+		service.decoderPools[mt] = p
+	}
+}
+
+// RegisterEncoder registers factory as the source of goahttp.Encoder values
+// for responses whose negotiated media type matches one of mediaTypes. The
+// media types are also appended, in the order given, to the list consulted
+// when a request's Accept header cannot be satisfied exactly - see Encoder.
+func (service *Service) RegisterEncoder(factory func(io.Writer) goahttp.Encoder, mediaTypes ...string) {
+	p := newEncoderPool(factory)
+	for _, mt := range mediaTypes {
+		if _, ok := service.encoderPools[mt]; !ok {
+			service.encodableContentTypes = append(service.encodableContentTypes, mt)
+		}
+		service.encoderPools[mt] = p
+	}
+}
+
+// registerDefaultCodecs wires up the encoders and decoders every service
+// supports out of the box. Callers may override any of them, or add new
+// ones, by calling RegisterEncoder / RegisterDecoder again with the same (or
+// an additional) media type.
+func (service *Service) registerDefaultCodecs() {
+	service.RegisterEncoder(func(w io.Writer) goahttp.Encoder { return goahttp.NewJSONEncoder(w) }, "application/json")
+	service.RegisterEncoder(func(w io.Writer) goahttp.Encoder { return goahttp.NewXMLEncoder(w) }, "application/xml")
+	service.RegisterEncoder(func(w io.Writer) goahttp.Encoder { return goahttp.NewMsgpackEncoder(w) }, "application/msgpack")
+	service.RegisterEncoder(func(w io.Writer) goahttp.Encoder { return goahttp.NewFormEncoder(w) }, "application/x-www-form-urlencoded")
+
+	service.RegisterDecoder(func(r io.Reader) goahttp.Decoder { return goahttp.NewJSONDecoder(r) }, "application/json")
+	service.RegisterDecoder(func(r io.Reader) goahttp.Decoder { return goahttp.NewXMLDecoder(r) }, "application/xml")
+	service.RegisterDecoder(func(r io.Reader) goahttp.Decoder { return goahttp.NewMsgpackDecoder(r) }, "application/msgpack")
+	service.RegisterDecoder(func(r io.Reader) goahttp.Decoder { return goahttp.NewFormDecoder(r) }, "application/x-www-form-urlencoded")
+}
+
+// Decode uses the request "Content-Type" header to look up a registered
+// decoder pool and unmarshals the request body into v. It defaults to
+// "application/json" when the request does not set a Content-Type. It
+// returns ErrInvalidEncoding if no decoder is registered for the resolved
+// media type, MissingPayloadError if the body is empty (the decoder returns
+// io.EOF) and a DecodePayloadError if the decoder fails otherwise.
+func (service *Service) Decode(ctx context.Context, r *http.Request, v interface{}) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	} else if mt, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mt
+	}
+	p, ok := service.decoderPools[contentType]
+	if !ok {
+		return ErrInvalidEncoding(fmt.Errorf("no decoder registered for %q", contentType))
+	}
+	dec := p.Get(r.Body)
+	defer p.Put(dec)
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return MissingPayloadError()
+		}
+		return DecodePayloadError(err.Error())
+	}
+	return nil
+}
+
+// Encoder negotiates the response media type from the "Accept" header of the
+// request stored in ctx, honoring "q" weights (entries with q <= 0 are
+// excluded, per RFC 7231) and "*/*" or "type/*" wildcards, and returns a
+// pooled goahttp.Encoder for it. It sets the "Content-Type" response header
+// as a side effect so callers must call it before writing the response
+// status. When the client sends no Accept header, or none of its
+// preferences are registered, Encoder falls back to the first media type
+// passed to RegisterEncoder. Callers must defer a call to ReleaseEncoder
+// once done with the returned encoder, or use Encode instead, so the
+// encoder is returned to its pool.
+func (service *Service) Encoder(ctx context.Context, w http.ResponseWriter) goahttp.Encoder {
+	var accept string
+	if req := ContextRequest(ctx); req != nil {
+		accept = req.Header.Get("Accept")
+	}
+	contentType, p := service.negotiateEncoder(accept)
+	w.Header().Set("Content-Type", contentType)
+	return p.Get(w)
+}
+
+// Encode is a convenience wrapper around Encoder that writes v to w and
+// returns the encoder to its pool once done.
+func (service *Service) Encode(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+	enc := service.Encoder(ctx, w)
+	defer service.ReleaseEncoder(w, enc)
+	return enc.Encode(v)
+}
+
+// ReleaseEncoder returns enc, previously obtained from Encoder for w, to its
+// pool. Callers that use Encoder directly - rather than through Encode -
+// must defer a call to ReleaseEncoder once they are done with enc, otherwise
+// the pool never reclaims it and every response allocates a fresh encoder.
+func (service *Service) ReleaseEncoder(w http.ResponseWriter, enc goahttp.Encoder) {
+	if p, ok := service.encoderPools[w.Header().Get("Content-Type")]; ok {
+		p.Put(enc)
+	}
+}
+
+// negotiateEncoder picks the best encoderPool for the given Accept header
+// value, falling back to the first registered content type.
+func (service *Service) negotiateEncoder(accept string) (string, *encoderPool) {
+	for _, e := range parseAccept(accept) {
+		if e.q <= 0 {
+			continue
+		}
+		switch {
+		case e.mediaType == "*/*":
+			if len(service.encodableContentTypes) > 0 {
+				ct := service.encodableContentTypes[0]
+				return ct, service.encoderPools[ct]
+			}
+		case strings.HasSuffix(e.mediaType, "/*"):
+			prefix := strings.TrimSuffix(e.mediaType, "*")
+			for _, ct := range service.encodableContentTypes {
+				if strings.HasPrefix(ct, prefix) {
+					return ct, service.encoderPools[ct]
+				}
+			}
+		default:
+			if p, ok := service.encoderPools[e.mediaType]; ok {
+				return e.mediaType, p
+			}
+		}
+	}
+	for _, ct := range service.encodableContentTypes {
+		return ct, service.encoderPools[ct]
+	}
+	return "", nil
+}
+
+// parseAccept splits an Accept header into its media types ordered from most
+// to least preferred according to their "q" parameter (defaulting to 1).
+func parseAccept(accept string) []acceptEntry {
+	if accept == "" {
+		return nil
+	}
+	parts := strings.Split(accept, ",")
+	entries := make([]acceptEntry, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(qs, 64); err == nil {
+				q = parsed
+			}
+		}
+		entries = append(entries, acceptEntry{mediaType: mt, q: q})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	return entries
+}
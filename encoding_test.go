@@ -0,0 +1,89 @@
+package goa
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	goahttp "goa.design/goa/http"
+)
+
+func TestParseAccept(t *testing.T) {
+	cases := []struct {
+		name   string
+		accept string
+		want   []acceptEntry
+	}{
+		{"empty", "", nil},
+		{"single", "application/json", []acceptEntry{{"application/json", 1}}},
+		{
+			"sorted by q",
+			"text/html;q=0.5, application/json;q=0.9, application/xml",
+			[]acceptEntry{
+				{"application/xml", 1},
+				{"application/json", 0.9},
+				{"text/html", 0.5},
+			},
+		},
+		{
+			"q=0 entries are kept by parseAccept",
+			"application/json;q=0",
+			[]acceptEntry{{"application/json", 0}},
+		},
+		{
+			"malformed entry is skipped",
+			"application/json, not a media type",
+			[]acceptEntry{{"application/json", 1}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := parseAccept(c.accept)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("parseAccept(%q) = %#v, want %#v", c.accept, got, c.want)
+			}
+		})
+	}
+}
+
+// fakeEncoder is a minimal goahttp.Encoder used to exercise negotiateEncoder
+// without depending on the real JSON/XML/... encoder implementations.
+type fakeEncoder struct{}
+
+func (fakeEncoder) Encode(interface{}) error { return nil }
+
+func newNegotiationTestService() *Service {
+	svc := New("test")
+	svc.RegisterEncoder(func(io.Writer) goahttp.Encoder { return fakeEncoder{} }, "application/json")
+	svc.RegisterEncoder(func(io.Writer) goahttp.Encoder { return fakeEncoder{} }, "application/xml")
+	return svc
+}
+
+func TestNegotiateEncoder(t *testing.T) {
+	svc := newNegotiationTestService()
+
+	cases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"no accept header falls back to first registered", "", "application/json"},
+		{"exact match", "application/xml", "application/xml"},
+		{"wildcard subtype", "application/*", "application/json"},
+		{"catch-all wildcard", "*/*", "application/json"},
+		{"unregistered preference falls back", "text/html", "application/json"},
+		{"q=0 refuses the otherwise best match", "application/json;q=0, application/xml;q=0.5", "application/xml"},
+		{"all candidates refused falls back to first registered", "application/json;q=0, application/xml;q=0", "application/json"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ct, p := svc.negotiateEncoder(c.accept)
+			if ct != c.want {
+				t.Errorf("negotiateEncoder(%q) content type = %q, want %q", c.accept, ct, c.want)
+			}
+			if p == nil {
+				t.Errorf("negotiateEncoder(%q) returned a nil pool", c.accept)
+			}
+		})
+	}
+}
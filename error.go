@@ -0,0 +1,136 @@
+package goa
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+type (
+	// Error is the original, minimal HTTP error type understood by the
+	// error handlers. New code should prefer ServiceError, which adds a
+	// stable code, metadata and an automatically captured call stack.
+	Error struct {
+		// Status is the response status code to write.
+		Status int
+		// Detail is a human readable description of the error.
+		Detail string
+	}
+
+	// ServiceError is a structured error carrying a stable machine readable
+	// Code, the HTTP Status to respond with, a human readable Detail message,
+	// arbitrary Meta data and the call stack captured when the error was
+	// constructed (via NewBadRequest, NewNotFound, NewInternal and friends).
+	ServiceError struct {
+		// Code is a short, stable identifier for the error, e.g.
+		// "bad_request" or "not_found".
+		Code string `json:"code"`
+		// Status is the HTTP status code to write.
+		Status int `json:"status"`
+		// Detail is a human readable description of the error.
+		Detail string `json:"detail,omitempty"`
+		// Meta carries arbitrary additional data about the error.
+		Meta map[string]interface{} `json:"meta,omitempty"`
+		// Stack holds file:line entries for the call stack captured at
+		// construction time, with frames inside the goa package trimmed. It
+		// is only written to the response when the handling Service has
+		// Debug set - see DefaultErrorHandler.
+		Stack []string `json:"stack,omitempty"`
+	}
+)
+
+// Error implements the error interface.
+func (e *Error) Error() string { return e.Detail }
+
+// Error implements the error interface.
+func (e *ServiceError) Error() string {
+	if e.Detail == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Detail)
+}
+
+// WithMeta sets a key/value pair in the error Meta map and returns the error
+// to allow chaining, e.g. goa.NewBadRequest("invalid id").WithMeta("id", id).
+func (e *ServiceError) WithMeta(key string, value interface{}) *ServiceError {
+	if e.Meta == nil {
+		e.Meta = make(map[string]interface{})
+	}
+	e.Meta[key] = value
+	return e
+}
+
+// newServiceError builds a ServiceError and captures the current call stack,
+// trimming the frames that belong to the goa package itself so the first
+// entry points at the caller.
+func newServiceError(code string, status int, detail string) *ServiceError {
+	return &ServiceError{
+		Code:   code,
+		Status: status,
+		Detail: detail,
+		Stack:  captureStack(),
+	}
+}
+
+// goaPackagePrefix identifies frame.Function values belonging to this
+// package, as opposed to any package living under the goa.design/goa module
+// path - e.g. goa.design/goa/middleware or goa.design/goa/examples/cellar.
+// A package-qualified function name always joins the import path and the
+// function with a ".", so only this package's own path is followed by "."
+// instead of "/".
+const goaPackagePrefix = "goa.design/goa."
+
+// captureStack walks the call stack starting at newServiceError's caller and
+// returns it as a list of "file:line" entries, skipping frames whose
+// function belongs to this package so the first entry points at the caller.
+func captureStack() []string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, goaPackagePrefix) {
+			stack = append(stack, fmt.Sprintf("%s:%d", frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// NewBadRequest builds a 400 ServiceError.
+func NewBadRequest(detail string) *ServiceError {
+	return newServiceError("bad_request", http.StatusBadRequest, detail)
+}
+
+// NewNotFound builds a 404 ServiceError.
+func NewNotFound(detail string) *ServiceError {
+	return newServiceError("not_found", http.StatusNotFound, detail)
+}
+
+// NewInternal builds a 500 ServiceError wrapping err, using its message as
+// the Detail.
+func NewInternal(err error) *ServiceError {
+	return newServiceError("internal", http.StatusInternalServerError, err.Error())
+}
+
+// ErrInvalidEncoding builds a 400 ServiceError reporting that a request could
+// not be decoded.
+func ErrInvalidEncoding(err error) *ServiceError {
+	return newServiceError("invalid_encoding", http.StatusBadRequest, err.Error())
+}
+
+// MissingPayloadError builds a 400 ServiceError reporting that a request was
+// sent with an empty body where one was expected.
+func MissingPayloadError() *ServiceError {
+	return newServiceError("missing_payload", http.StatusBadRequest, "missing request body")
+}
+
+// DecodePayloadError builds a 400 ServiceError reporting that the request
+// body could not be decoded, detail being the underlying decoder error.
+func DecodePayloadError(detail string) *ServiceError {
+	return newServiceError("invalid_payload", http.StatusBadRequest, detail)
+}
@@ -0,0 +1,42 @@
+package goa
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaptureStack(t *testing.T) {
+	stack := NewBadRequest("boom").Stack
+	if len(stack) == 0 {
+		t.Fatal("captureStack returned an empty stack; the testing package frames below this test should remain")
+	}
+}
+
+// TestCaptureStackTrimsOwnPackage builds an error through NewBadRequest, so
+// the frame right after the constructor's belongs to this very (package goa)
+// test function. It must be trimmed, leaving the stdlib testing frames that
+// invoked it.
+func TestCaptureStackTrimsOwnPackage(t *testing.T) {
+	for _, frame := range NewBadRequest("boom").Stack {
+		if strings.Contains(frame, "error_test.go") {
+			t.Errorf("captureStack leaked its own package's frame: %s", frame)
+		}
+	}
+}
+
+func TestGoaPackagePrefixDoesNotMatchSubpackages(t *testing.T) {
+	cases := []struct {
+		function string
+		want     bool
+	}{
+		{"goa.design/goa.newServiceError", true},
+		{"goa.design/goa.(*Service).Decode", true},
+		{"goa.design/goa/middleware.RequireHeader", false},
+		{"goa.design/goa/examples/cellar.main", false},
+	}
+	for _, c := range cases {
+		if got := strings.HasPrefix(c.function, goaPackagePrefix); got != c.want {
+			t.Errorf("goaPackagePrefix match for %q = %v, want %v", c.function, got, c.want)
+		}
+	}
+}
@@ -10,7 +10,6 @@ package server
 
 import (
 	"context"
-	"io"
 	"net/http"
 
 	goa "goa.design/goa"
@@ -19,11 +18,13 @@ import (
 )
 
 // EncodePickResponse returns an encoder for responses returned by the
-// sommelier pick endpoint.
-func EncodePickResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+// sommelier pick endpoint. The encoder is negotiated from the request
+// "Accept" header by svc - see goa.Service.Encoder.
+func EncodePickResponse(svc *goa.Service) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
 		res := v.(sommelier.StoredBottleCollection)
-		enc := encoder(ctx, w)
+		enc := svc.Encoder(ctx, w)
+		defer svc.ReleaseEncoder(w, enc)
 		body := NewPickResponseBody(res)
 		w.WriteHeader(http.StatusOK)
 		return enc.Encode(body)
@@ -31,19 +32,14 @@ func EncodePickResponse(encoder func(context.Context, http.ResponseWriter) goaht
 }
 
 // DecodePickRequest returns a decoder for requests sent to the sommelier pick
-// endpoint.
-func DecodePickRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+// endpoint. The decoder is looked up from the request "Content-Type" header
+// by svc - see goa.Service.Decode, which already turns an empty body into
+// goa.MissingPayloadError().
+func DecodePickRequest(mux goahttp.Muxer, svc *goa.Service) func(*http.Request) (interface{}, error) {
 	return func(r *http.Request) (interface{}, error) {
-		var (
-			body PickRequestBody
-			err  error
-		)
-		err = decoder(r).Decode(&body)
-		if err != nil {
-			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
-			}
-			return nil, goa.DecodePayloadError(err.Error())
+		var body PickRequestBody
+		if err := svc.Decode(r.Context(), r, &body); err != nil {
+			return nil, err
 		}
 
 		return NewPickCriteria(&body), nil
@@ -51,25 +47,27 @@ func DecodePickRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.De
 }
 
 // EncodePickError returns an encoder for errors returned by the pick sommelier
-// endpoint.
-func EncodePickError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
-	encodeError := goahttp.ErrorEncoder(encoder)
+// endpoint. The encoder is negotiated from the request "Accept" header by
+// svc - see goa.Service.Encoder.
+func EncodePickError(svc *goa.Service) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(svc.Encoder)
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
 		switch res := v.(type) {
 		case sommelier.NoCriteria:
-			enc := encoder(ctx, w)
+			enc := svc.Encoder(ctx, w)
+			defer svc.ReleaseEncoder(w, enc)
 			body := NewPickNoCriteriaResponseBody(res)
 			w.WriteHeader(http.StatusBadRequest)
 			return enc.Encode(body)
 		case sommelier.NoMatch:
-			enc := encoder(ctx, w)
+			enc := svc.Encoder(ctx, w)
+			defer svc.ReleaseEncoder(w, enc)
 			body := NewPickNoMatchResponseBody(res)
 			w.WriteHeader(http.StatusNotFound)
 			return enc.Encode(body)
 		default:
 			return encodeError(ctx, w, v)
 		}
-		return nil
 	}
 }
 
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	goa "goa.design/goa"
+)
+
+// CORSOptions configures the CORS middleware.
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin requests.
+	// "*" allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods lists the methods advertised in the preflight response.
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers advertised in the preflight
+	// response.
+	AllowedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials when true.
+	AllowCredentials bool
+	// MaxAge sets Access-Control-Max-Age, in seconds, on preflight responses
+	// when greater than zero.
+	MaxAge int
+}
+
+// CORS returns a middleware that sets the Access-Control-* response headers
+// for requests whose Origin is allowed by opts and, for OPTIONS preflight
+// requests, writes the response and aborts the chain without invoking the
+// inner handler.
+func CORS(opts CORSOptions) goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			origin := req.Header.Get("Origin")
+			if origin != "" && originAllowed(opts.AllowedOrigins, origin) {
+				header := rw.Header()
+				header.Set("Access-Control-Allow-Origin", origin)
+				if opts.AllowCredentials {
+					header.Set("Access-Control-Allow-Credentials", "true")
+				}
+				if len(opts.AllowedHeaders) > 0 {
+					header.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				}
+			}
+			if req.Method != http.MethodOptions {
+				return h(ctx, rw, req)
+			}
+			if len(opts.AllowedMethods) > 0 {
+				rw.Header().Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+			}
+			if opts.MaxAge > 0 {
+				rw.Header().Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+	}
+}
+
+// originAllowed returns true if allowed contains "*" or origin itself.
+func originAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+	}
+	return false
+}
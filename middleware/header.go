@@ -0,0 +1,55 @@
+/*
+Package middleware provides goa middleware that is commonly needed by
+services but does not belong in the core goa package, such as enforcing
+required headers and handling CORS preflight requests.
+*/
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"golang.org/x/net/context"
+
+	goa "goa.design/goa"
+)
+
+// RequireHeader returns a middleware that, for requests whose path matches
+// pathPattern, requires the headerName header to be present and to match
+// valuePattern. Requests that don't satisfy this are aborted: the inner
+// handler is never invoked and failureStatus is written as the response
+// using http.StatusText(failureStatus) as the body.
+func RequireHeader(pathPattern *regexp.Regexp, headerName string, valuePattern *regexp.Regexp, failureStatus int) goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			if pathPattern.MatchString(req.URL.Path) {
+				if v := req.Header.Get(headerName); v == "" || !valuePattern.MatchString(v) {
+					return goa.ContextResponse(ctx).Send(ctx, failureStatus, http.StatusText(failureStatus))
+				}
+			}
+			return h(ctx, rw, req)
+		}
+	}
+}
+
+// RequireAnyHeader is like RequireHeader except it succeeds as soon as one of
+// headerNames is present and matches valuePattern.
+func RequireAnyHeader(pathPattern *regexp.Regexp, headerNames []string, valuePattern *regexp.Regexp, failureStatus int) goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			if pathPattern.MatchString(req.URL.Path) {
+				satisfied := false
+				for _, name := range headerNames {
+					if v := req.Header.Get(name); v != "" && valuePattern.MatchString(v) {
+						satisfied = true
+						break
+					}
+				}
+				if !satisfied {
+					return goa.ContextResponse(ctx).Send(ctx, failureStatus, http.StatusText(failureStatus))
+				}
+			}
+			return h(ctx, rw, req)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package goa
+
+// RouterGroup mounts routes under a common path prefix behind a shared
+// middleware chain. Use Service.Group to create one; see RouterGroup.Handle.
+type RouterGroup struct {
+	service    *Service
+	prefix     string
+	middleware []Middleware
+}
+
+// Group returns a RouterGroup mounting routes under prefix. mws run, in
+// order, after the service-wide middleware chain (Service.Use) and before
+// any per-route middleware and the route handler itself.
+func (service *Service) Group(prefix string, mws ...Middleware) *RouterGroup {
+	return &RouterGroup{service: service, prefix: prefix, middleware: mws}
+}
+
+// Group returns a sub-group nesting prefix under g's own prefix and running
+// g's middleware ahead of mws.
+func (g *RouterGroup) Group(prefix string, mws ...Middleware) *RouterGroup {
+	middleware := make([]Middleware, 0, len(g.middleware)+len(mws))
+	middleware = append(middleware, g.middleware...)
+	middleware = append(middleware, mws...)
+	return &RouterGroup{service: g.service, prefix: g.prefix + prefix, middleware: middleware}
+}
+
+// Handle mounts h on method and g.prefix+path. The middleware chain invoked
+// for the route is, in order: the service-wide middleware (Service.Use), g's
+// own middleware (and that of any parent group), then mws. No payload
+// unmarshaler is installed; use HandleUnmarshal for that.
+func (g *RouterGroup) Handle(method, path string, h Handler, mws ...Middleware) {
+	g.HandleUnmarshal(method, path, h, nil, mws...)
+}
+
+// HandleUnmarshal is like Handle but also takes the Unmarshaler used to
+// decode the request payload, for generated code that needs one. unm, if not
+// nil, unmarshals the request payload before the chain runs - see
+// Controller.MuxHandler.
+func (g *RouterGroup) HandleUnmarshal(method, path string, h Handler, unm Unmarshaler, mws ...Middleware) {
+	ctrl := g.service.NewController(g.prefix)
+	full := make([]Middleware, 0, len(ctrl.Middleware)+len(g.middleware)+len(mws))
+	full = append(full, ctrl.Middleware...)
+	full = append(full, g.middleware...)
+	full = append(full, mws...)
+	ctrl.Middleware = full
+	mh := ctrl.MuxHandler(method+" "+g.prefix+path, h, unm)
+	g.service.Mux.Handle(method, g.prefix+path, mh)
+}
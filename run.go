@@ -0,0 +1,134 @@
+package goa
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// defaultShutdownTimeout is the grace period given to in-flight requests when
+// Service.ShutdownTimeout is left at its zero value.
+const defaultShutdownTimeout = 15 * time.Second
+
+// Run starts a HTTP server listening on addr and blocks until it exits,
+// either because it failed to start or because it received a SIGINT or
+// SIGTERM. On signal it stops accepting new connections and waits up to
+// Service.ShutdownTimeout for in-flight requests to complete, running any
+// hooks registered with OnShutdown, before returning. If GRPCServer has been
+// started via ListenAndServeGRPC, it is gracefully stopped at the same time.
+func (service *Service) Run(addr string) error {
+	service.LogInfo("listen", "transport", "http", "addr", addr)
+	srv := &http.Server{Addr: addr, Handler: service.Mux}
+	return service.serve(srv, srv.ListenAndServe)
+}
+
+// RunTLS behaves like Run but starts a HTTPS server using the given
+// certificate and key files.
+func (service *Service) RunTLS(addr, certFile, keyFile string) error {
+	service.LogInfo("listen", "transport", "https", "addr", addr)
+	srv := &http.Server{Addr: addr, Handler: service.Mux}
+	return service.serve(srv, func() error { return srv.ListenAndServeTLS(certFile, keyFile) })
+}
+
+// GRPCServer lazily creates and returns the grpc.Server backing
+// ListenAndServeGRPC. Generated Mount functions register their service
+// implementations on it, typically before the server starts listening:
+//
+//	sommelierserver.Mount(service.GRPCServer(), sommelierserver.New(pick))
+//	service.ListenAndServeGRPC(":8080")
+func (service *Service) GRPCServer() *grpc.Server {
+	if service.grpcServer == nil {
+		service.grpcServer = grpc.NewServer()
+	}
+	return service.grpcServer
+}
+
+// ListenAndServeGRPC starts a gRPC server listening on addr and serves the
+// services registered on GRPCServer(). It can run alongside Run/RunTLS so a
+// single Service instance serves both HTTP and gRPC, typically on different
+// ports, sharing the same endpoint functions.
+func (service *Service) ListenAndServeGRPC(addr string) error {
+	service.LogInfo("listen", "transport", "grpc", "addr", addr)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return service.GRPCServer().Serve(lis)
+}
+
+// OnShutdown registers fn to be called with the shutdown context once Run or
+// RunTLS has stopped its servers after a SIGINT or SIGTERM, e.g. to close a
+// database connection or flush traces. Hooks run in the order they were registered; a hook returning an
+// error does not stop the remaining hooks from running.
+func (service *Service) OnShutdown(fn func(context.Context) error) {
+	service.shutdownHooks = append(service.shutdownHooks, fn)
+}
+
+// serve runs listen in the background and waits for it to return or for a
+// SIGINT/SIGTERM to trigger a graceful shutdown of srv.
+func (service *Service) serve(srv *http.Server, listen func() error) error {
+	errc := make(chan error, 1)
+	go func() { errc <- listen() }()
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	select {
+	case err := <-errc:
+		return err
+	case sig := <-sigc:
+		service.LogInfo("shutdown", "signal", sig.String())
+	}
+
+	// Cancel in-flight handlers first so they can observe ctx.Done() and
+	// return early instead of running out the full shutdown timeout.
+	service.CancelAll()
+
+	timeout := service.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Stop the gRPC server, if any, concurrently with the HTTP server so
+	// neither has to wait out the other's share of timeout.
+	var wg sync.WaitGroup
+	if grpcServer := service.grpcServer; grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+		}()
+	}
+
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		// The grace period ran out: close the connections still open.
+		srv.Close()
+	}
+	wg.Wait()
+	for _, hook := range service.shutdownHooks {
+		if hookErr := hook(ctx); hookErr != nil && err == nil {
+			err = hookErr
+		}
+	}
+	return err
+}
@@ -10,8 +10,11 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 type (
@@ -48,11 +51,28 @@ type (
 		Middleware []Middleware
 		// Service-wide error handler
 		ErrorHandler ErrorHandler
+		// ShutdownTimeout bounds how long Run and RunTLS wait for in-flight
+		// requests to complete after receiving SIGINT or SIGTERM before
+		// forcibly closing the server. It defaults to 15 seconds when zero.
+		ShutdownTimeout time.Duration
+		// Debug, when true, makes DefaultErrorHandler include the captured
+		// call stack of ServiceError values in the response body.
+		Debug bool
 
 		cancel                context.CancelFunc
 		decoderPools          map[string]*decoderPool // Registered decoders for the service
 		encoderPools          map[string]*encoderPool // Registered encoders for the service
 		encodableContentTypes []string                // List of contentTypes for response negotiation
+		ctxPool               sync.Pool               // Pool of reusable RequestData/ResponseData pairs
+		shutdownHooks         []func(context.Context) error
+		grpcServer            *grpc.Server
+	}
+
+	// requestState bundles the RequestData/ResponseData pair drawn from and
+	// returned to Service.ctxPool for each request.
+	requestState struct {
+		req  *RequestData
+		resp *ResponseData
 	}
 
 	// Controller provides the common state and behavior for generated controllers.
@@ -84,7 +104,7 @@ func New(name string) *Service {
 	stdlog := log.New(os.Stderr, "", log.LstdFlags)
 	ctx := UseLogger(context.Background(), NewStdLogger(stdlog))
 	ctx, cancel := context.WithCancel(ctx)
-	return &Service{
+	service := &Service{
 		Name:         name,
 		ErrorHandler: DefaultErrorHandler,
 		Context:      ctx,
@@ -95,6 +115,8 @@ func New(name string) *Service {
 		encoderPools:          map[string]*encoderPool{},
 		encodableContentTypes: []string{},
 	}
+	service.registerDefaultCodecs()
+	return service
 }
 
 // CancelAll sends a cancel signals to all request handlers via the context.
@@ -209,7 +231,10 @@ func (ctrl *Controller) Use(m Middleware) {
 // handler.
 func (ctrl *Controller) HandleError(ctx context.Context, rw http.ResponseWriter, req *http.Request, err error) {
 	status := 500
-	if e, ok := err.(*Error); ok {
+	switch e := err.(type) {
+	case *ServiceError:
+		status = e.Status
+	case *Error:
 		status = e.Status
 	}
 	go IncrCounter([]string{"goa", "handler", "error", strconv.Itoa(status)}, 1.0)
@@ -222,9 +247,35 @@ func (ctrl *Controller) HandleError(ctx context.Context, rw http.ResponseWriter,
 	}
 }
 
+// acquireRequestState returns a RequestData/ResponseData pair from the pool,
+// allocating a new pair if the pool is empty.
+func (service *Service) acquireRequestState() (*RequestData, *ResponseData) {
+	if v := service.ctxPool.Get(); v != nil {
+		s := v.(*requestState)
+		return s.req, s.resp
+	}
+	return &RequestData{}, &ResponseData{}
+}
+
+// releaseRequestState clears req and resp and returns them to the pool. It
+// must only be called once the handler chain they were built for has
+// returned - callers must not retain req, resp or the context built from them
+// past that point.
+func (service *Service) releaseRequestState(req *RequestData, resp *ResponseData) {
+	req.Request = nil
+	req.Params = nil
+	resp.ResponseWriter = nil
+	resp.Status = 0
+	resp.Length = 0
+	service.ctxPool.Put(&requestState{req: req, resp: resp})
+}
+
 // MuxHandler wraps a request handler into a MuxHandler. The MuxHandler initializes the
 // request context by loading the request state, invokes the handler and in case of error invokes
 // the controller (if there is one) or Service error handler.
+// The RequestData and ResponseData values backing the context are drawn from
+// a pool and returned to it once the handler chain returns, so handlers must
+// not retain ctx, rw or any value derived from them past that point.
 // This function is intended for the controller generated code. User code should not need to call
 // it directly.
 func (ctrl *Controller) MuxHandler(name string, hdlr Handler, unm Unmarshaler) MuxHandler {
@@ -268,6 +319,10 @@ func (ctrl *Controller) MuxHandler(name string, hdlr Handler, unm Unmarshaler) M
 
 		// Invoke middleware chain, wrap writer to capture response status and length
 		handler(ctx, ContextResponse(ctx), req)
+
+		if svc := ContextService(ctx); svc != nil {
+			svc.releaseRequestState(ContextRequest(ctx), ContextResponse(ctx))
+		}
 	}
 }
 
@@ -278,6 +333,13 @@ func DefaultErrorHandler(ctx context.Context, rw http.ResponseWriter, req *http.
 	status := 500
 	var respBody interface{}
 	switch err := e.(type) {
+	case *ServiceError:
+		status = err.Status
+		body := *err
+		if svc := ContextService(ctx); svc == nil || !svc.Debug {
+			body.Stack = nil
+		}
+		respBody = &body
 	case *Error:
 		status = err.Status
 		respBody = err
@@ -296,6 +358,13 @@ func TerseErrorHandler(ctx context.Context, rw http.ResponseWriter, req *http.Re
 	status := 500
 	var respBody interface{}
 	switch err := e.(type) {
+	case *ServiceError:
+		status = err.Status
+		if status != 500 {
+			body := *err
+			body.Stack = nil
+			respBody = &body
+		}
 	case *Error:
 		status = err.Status
 		if status != 500 {